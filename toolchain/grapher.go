@@ -2,6 +2,7 @@ package python
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -9,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 	"text/template"
+	"time"
 
 	"sourcegraph.com/sourcegraph/srcgraph/config"
 	"sourcegraph.com/sourcegraph/srcgraph/container"
@@ -17,6 +19,7 @@ import (
 	"sourcegraph.com/sourcegraph/srcgraph/repo"
 	"sourcegraph.com/sourcegraph/srcgraph/task2"
 	"sourcegraph.com/sourcegraph/srcgraph/unit"
+	"sourcegraph.com/sourcegraph/srclib-python/toolchain/schema"
 )
 
 func init() {
@@ -28,6 +31,39 @@ const stdLibRepo = repo.URI("hg.python.org/cpython")
 
 var builtinPrefixes = map[string]string{"sys": "sys", "os": "os", "path": "os/path"}
 
+// analyzerEnvVar overrides pythonEnv.Analyzer, letting a deployment switch
+// backends without rebuilding srclib-python.
+const analyzerEnvVar = "SRCLIB_PYTHON_ANALYZER"
+
+// Analyzer builds the Dockerfile and in-container command used to turn a
+// Python source tree into the raw graph JSON consumed by BuildGrapher's
+// Transform. PysonarAnalyzer is the original JVM-based backend; JediAnalyzer
+// runs entirely inside the venv and avoids the Java/Maven/pysonar2 toolchain.
+type Analyzer interface {
+	Dockerfile(p *pythonEnv) []byte
+	Cmd(p *pythonEnv) []string
+}
+
+// analyzer picks the Analyzer for this environment: the SRCLIB_PYTHON_ANALYZER
+// env var takes precedence over the Analyzer field, and PysonarAnalyzer is
+// the default so existing configs keep their current behavior.
+func (p *pythonEnv) analyzer() Analyzer {
+	name := p.Analyzer
+	if v := os.Getenv(analyzerEnvVar); v != "" {
+		name = v
+	}
+	switch name {
+	case "jedi":
+		return JediAnalyzer{}
+	default:
+		return PysonarAnalyzer{}
+	}
+}
+
+// PysonarAnalyzer graphs Python source with the sourcegraph/pysonar2 Java
+// tool, run inside a container that also needs a JDK and Maven to build it.
+type PysonarAnalyzer struct{}
+
 var grapherDockerfileTemplate = template.Must(template.New("").Parse(`FROM dockerfile/java
 RUN apt-get update
 RUN apt-get install -qy curl
@@ -55,6 +91,10 @@ WORKDIR /pysonar2
 RUN mvn clean package
 WORKDIR /
 
+# Typeshed stubs, used to resolve C-extension symbols pysonar can't trace
+# back to a real .py def site
+RUN git clone --depth 1 https://github.com/python/typeshed.git /typeshed
+
 # Set up virtualenv (will contain dependencies)
 RUN virtualenv /venv
 `))
@@ -65,17 +105,78 @@ var grapherDockerCmdTemplate = template.Must(template.New("").Parse(`
 # Compute requirements
 REQDATA=$(pydep-run.py {{.SrcDir}});
 
+# Merge in editable / local-path requirements (pip install -e ..., file://
+# reqs) that pydep can't see: each one leaves behind an .egg-link in
+# site-packages pointing at the real source tree instead of a downloaded
+# sdist.
+REQDATA=$(REQDATA="$REQDATA" python - <<'EOF'
+import glob, json, os, subprocess
+
+reqs = json.loads(os.environ['REQDATA'])
+byName = dict((r.get('Name'), r) for r in reqs)
+
+for eggLink in glob.glob('{{.SitePackages}}/*.egg-link'):
+    name = os.path.basename(eggLink)[:-len('.egg-link')]
+    with open(eggLink) as f:
+        srcPath = f.readline().strip()
+
+    location = srcPath
+    info = subprocess.check_output(['/venv/bin/pip', 'show', '-f', name]).decode()
+    for line in info.splitlines():
+        if line.startswith('Location: '):
+            location = line[len('Location: '):].strip()
+            break
+
+    req = byName.get(name)
+    if req is None:
+        # pydep never heard of this package (it's editable-only), so there's
+        # no RepoURL to report; emit it anyway so every Reqs[] entry still
+        # has the key the schema requires.
+        req = {'Name': name, 'RepoURL': '', 'Packages': [], 'Modules': []}
+        reqs.append(req)
+    req['EditableInstall'] = location
+
+print(json.dumps(reqs))
+EOF
+);
+
 # Compute graph
 echo 'Running graphing step...' 1>&2;
 mkfifo /tmp/pysonar.err;
+# No SIGTERM trap here: cancellation kills this container outright with
+# SIGKILL (see container.Command), which nothing in this script can catch or
+# react to, so there is no fifo-reader handoff for a trap to perform. The
+# host-side Transform already bails out via ctx.Err() before touching
+# whatever partial output such a kill leaves behind.
 cat -v /tmp/pysonar.err &> /dev/null &  # bug: container hangs if we print this output
 GRAPHDATA=$(java {{.JavaOpts}} -classpath /pysonar2/target/pysonar-2.0-SNAPSHOT.jar org.yinwang.pysonar.JSONDump {{.SrcDir}} '{{.IncludePaths}}' '' 2>/tmp/pysonar.err);
 echo 'Graphing done.' 1>&2;
 
-echo "{ \"graph\": $GRAPHDATA, \"reqs\": $REQDATA }";
+# Index the typeshed checkout here, inside the container, and carry it home
+# in the JSON payload: Transform runs on the host long after this container
+# (and the /typeshed clone living only inside it) has exited.
+TYPESHED=$(python - <<'EOF'
+import json, os
+
+def pyi_modules(d):
+    if not os.path.isdir(d):
+        return []
+    return [f[:-len('.pyi')] for f in os.listdir(d) if f.endswith('.pyi')]
+
+stubsDir = '/typeshed/stubs'
+stubs = {}
+if os.path.isdir(stubsDir):
+    for pkg in os.listdir(stubsDir):
+        stubs[pkg] = pyi_modules(os.path.join(stubsDir, pkg))
+
+print(json.dumps({'Stdlib': pyi_modules('/typeshed/stdlib'), 'Stubs': stubs}))
+EOF
+);
+
+echo "{ \"Graph\": $GRAPHDATA, \"Reqs\": $REQDATA, \"Typeshed\": $TYPESHED }";
 `))
 
-func (p *pythonEnv) grapherDockerfile() []byte {
+func (PysonarAnalyzer) Dockerfile(p *pythonEnv) []byte {
 	var buf bytes.Buffer
 	grapherDockerfileTemplate.Execute(&buf, struct {
 		Python  string
@@ -97,7 +198,7 @@ func (p *pythonEnv) sitePackagesDir() string {
 	return filepath.Join("/venv", "lib", p.PythonVersion, "site-packages")
 }
 
-func (p *pythonEnv) grapherCmd() []string {
+func (PysonarAnalyzer) Cmd(p *pythonEnv) []string {
 	javaOpts := os.Getenv("PYGRAPH_JAVA_OPTS")
 	inclpaths := []string{srcRoot, p.stdLibDir(), p.sitePackagesDir()}
 
@@ -106,24 +207,225 @@ func (p *pythonEnv) grapherCmd() []string {
 		JavaOpts     string
 		SrcDir       string
 		IncludePaths string
+		SitePackages string
 	}{
 		JavaOpts:     javaOpts,
 		SrcDir:       srcRoot,
 		IncludePaths: strings.Join(inclpaths, ":"),
+		SitePackages: p.sitePackagesDir(),
+	})
+	return []string{"/bin/bash", "-c", buf.String()}
+}
+
+// JediAnalyzer graphs Python source with github.com/davidhalter/jedi, run as
+// a plain script inside the venv. It needs no JDK, Maven, or pysonar2 clone,
+// so the image is smaller and faster to build than PysonarAnalyzer's.
+type JediAnalyzer struct{}
+
+var jediDockerfileTemplate = template.Must(template.New("").Parse(`FROM ubuntu:14.04
+RUN apt-get update
+RUN apt-get install -qy curl
+RUN apt-get install -qy git
+RUN apt-get install -qy {{.Python}}
+RUN ln -s $(which {{.Python}}) /usr/bin/python
+RUN curl https://raw.githubusercontent.com/pypa/pip/cdee19c77cf6514d42e2d1b7134f10b8ed36b63a/contrib/get-pip.py > /tmp/get-pip.py
+RUN python /tmp/get-pip.py
+RUN pip install virtualenv
+
+# PyDep
+RUN pip install git+git://github.com/sourcegraph/pydep@0.0
+
+# Typeshed stubs, used to resolve C-extension symbols
+RUN git clone --depth 1 https://github.com/python/typeshed.git /typeshed
+
+# Set up virtualenv (will contain dependencies) and the analyzer itself.
+# jedi==0.9.0 predates Script.get_names()/Definition.infer(), which the
+# analyzer script below depends on; 0.17.2 is the last release before the
+# 0.18 Script(code=...) keyword-argument rename.
+RUN virtualenv /venv
+RUN /venv/bin/pip install jedi==0.17.2
+`))
+
+var jediDockerCmdTemplate = template.Must(template.New("").Parse(`
+/venv/bin/pip install {{.SrcDir}} 1>&2 || /venv/bin/pip install -r {{.SrcDir}}/requirements.txt 1>&2;
+
+# Compute requirements
+REQDATA=$(pydep-run.py {{.SrcDir}});
+
+# Compute graph
+echo 'Running graphing step...' 1>&2;
+GRAPHDATA=$(/venv/bin/python - {{.SrcDir}} <<'EOF'
+import json, os, sys
+import jedi
+
+srcDir = sys.argv[1]
+syms, refs, docs = [], [], []
+
+# Jedi's name.type vocabulary ('module'/'class'/'function'/'statement'/
+# 'param'/'instance') doesn't match pysonar's ('MODULE'/'CLASS'/'FUNC'/
+# 'VAR'/'PARAM'), which symbolKinds/callableSymbolKinds key off of.
+KIND_MAP = {
+    'module': 'MODULE',
+    'class': 'CLASS',
+    'function': 'FUNC',
+    'param': 'PARAM',
+    'statement': 'VAR',
+    'instance': 'VAR',
+}
+
+def kind_of(name):
+    return KIND_MAP.get(name.type, 'VAR')
+
+def line_offsets(source):
+    offsets = [0]
+    for line in source.splitlines(True):
+        offsets.append(offsets[-1] + len(line))
+    return offsets
+
+def offset_of(offsets, line, column):
+    return offsets[line - 1] + column
+
+for root, _, files in os.walk(srcDir):
+    for fname in files:
+        if not fname.endswith('.py'):
+            continue
+        path = os.path.join(root, fname)
+        with open(path) as f:
+            source = f.read()
+        offsets = line_offsets(source)
+
+        script = jedi.Script(source, path=path)
+        for name in script.get_names(all_scopes=True, definitions=True, references=True):
+            if name.is_definition():
+                if name.type == 'module' and name.module_path and str(name.module_path) != path:
+                    # An "import x" binding, not a module/package declaration
+                    # for this file: jedi reports it as a 'module'-kind
+                    # definition here, but its File is the importing file,
+                    # not x's, so treating it like a real module symbol
+                    # would corrupt __init__.py/namespace-package detection.
+                    continue
+                identStart = offset_of(offsets, name.line, name.column)
+                identEnd = identStart + len(name.name)
+                syms.append({
+                    'Path': '%s:%s' % (path, name.name),
+                    'Name': name.name,
+                    'File': path,
+                    'IdentStart': identStart,
+                    'IdentEnd': identEnd,
+                    'DefStart': identStart,
+                    'DefEnd': identEnd,
+                    'Exported': not name.name.startswith('_'),
+                    'Kind': kind_of(name),
+                })
+                continue
+
+            try:
+                targets = name.goto() or name.infer()
+            except Exception:
+                targets = []
+            for target in targets:
+                if not target.module_path:
+                    continue
+                start = offset_of(offsets, name.line, name.column)
+                refs.append({
+                    'Sym': '%s:%s' % (target.module_path, target.name),
+                    'File': path,
+                    'Start': start,
+                    'End': start + len(name.name),
+                    'Builtin': False,
+                })
+
+print(json.dumps({'Syms': syms, 'Refs': refs, 'Docs': docs}))
+EOF
+);
+echo 'Graphing done.' 1>&2;
+
+# Index the typeshed checkout here, inside the container, and carry it home
+# in the JSON payload: Transform runs on the host long after this container
+# (and the /typeshed clone living only inside it) has exited.
+TYPESHED=$(/venv/bin/python - <<'EOF'
+import json, os
+
+def pyi_modules(d):
+    if not os.path.isdir(d):
+        return []
+    return [f[:-len('.pyi')] for f in os.listdir(d) if f.endswith('.pyi')]
+
+stubsDir = '/typeshed/stubs'
+stubs = {}
+if os.path.isdir(stubsDir):
+    for pkg in os.listdir(stubsDir):
+        stubs[pkg] = pyi_modules(os.path.join(stubsDir, pkg))
+
+print(json.dumps({'Stdlib': pyi_modules('/typeshed/stdlib'), 'Stubs': stubs}))
+EOF
+);
+
+echo "{ \"Graph\": $GRAPHDATA, \"Reqs\": $REQDATA, \"Typeshed\": $TYPESHED }";
+`))
+
+func (JediAnalyzer) Dockerfile(p *pythonEnv) []byte {
+	var buf bytes.Buffer
+	jediDockerfileTemplate.Execute(&buf, struct {
+		Python string
+	}{
+		Python: p.PythonVersion,
+	})
+	return buf.Bytes()
+}
+
+func (JediAnalyzer) Cmd(p *pythonEnv) []string {
+	var buf bytes.Buffer
+	jediDockerCmdTemplate.Execute(&buf, struct {
+		SrcDir string
+	}{
+		SrcDir: srcRoot,
 	})
 	return []string{"/bin/bash", "-c", buf.String()}
 }
 
+// defaultPygraphTimeout bounds how long the pip install + graphing pipeline
+// may run before its container is killed, in case PYGRAPH_TIMEOUT isn't set.
+const defaultPygraphTimeout = 10 * time.Minute
+
+// pygraphTimeout reads PYGRAPH_TIMEOUT (a duration string like "5m") to
+// override defaultPygraphTimeout.
+func pygraphTimeout() time.Duration {
+	if v := os.Getenv("PYGRAPH_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return defaultPygraphTimeout
+}
+
 func (p *pythonEnv) BuildGrapher(dir string, unit unit.SourceUnit, c *config.Repository, x *task2.Context) (*container.Command, error) {
+	ctx := x.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, pygraphTimeout())
+
+	analyzer := p.analyzer()
 	return &container.Command{
+		Context: ctx,
 		Container: container.Container{
 			RunOptions: []string{"-v", dir + ":" + srcRoot},
-			Dockerfile: p.grapherDockerfile(),
-			Cmd:        p.grapherCmd(),
+			Dockerfile: analyzer.Dockerfile(p),
+			Cmd:        analyzer.Cmd(p),
 			Stderr:     x.Stderr,
 			Stdout:     x.Stdout,
 		},
 		Transform: func(orig []byte) ([]byte, error) {
+			defer cancel()
+			if err := ctx.Err(); err != nil {
+				return nil, fmt.Errorf("grapher container for %s was cancelled: %s", c.URI, err)
+			}
+
+			if err := schema.ValidateRawGraph(orig); err != nil {
+				return nil, err
+			}
+
 			var o rawGraphData
 			err := json.Unmarshal(orig, &o)
 			if err != nil {
@@ -140,9 +442,10 @@ func (p *pythonEnv) BuildGrapher(dir string, unit unit.SourceUnit, c *config.Rep
 				Docs:    make([]*graph.Doc, 0),
 			}
 
+			nsPkgDirs := namespacePackageDirs(o.Graph.Syms)
 			selfrefs := make(map[graph.Ref]struct{})
 			for _, psym := range o.Graph.Syms {
-				sym, selfref, err := p.convertSym(psym, c, o.Reqs)
+				sym, selfref, err := p.convertSym(psym, c, o.Reqs, nsPkgDirs, o.Typeshed)
 				if err != nil {
 					return nil, fmt.Errorf("could not convert sym %+v: %s", psym, err)
 				}
@@ -156,7 +459,7 @@ func (p *pythonEnv) BuildGrapher(dir string, unit unit.SourceUnit, c *config.Rep
 				}
 			}
 			for _, pref := range o.Graph.Refs {
-				if ref, err := p.convertRef(pref, c, o.Reqs); err == nil {
+				if ref, err := p.convertRef(pref, c, o.Reqs, o.Typeshed); err == nil {
 					if _, exists := selfrefs[*ref]; !exists {
 						o2.Refs = append(o2.Refs, ref)
 					}
@@ -165,7 +468,7 @@ func (p *pythonEnv) BuildGrapher(dir string, unit unit.SourceUnit, c *config.Rep
 				}
 			}
 			for _, pdoc := range o.Graph.Docs {
-				doc, err := p.convertDoc(pdoc, c, o.Reqs)
+				doc, err := p.convertDoc(pdoc, c, o.Reqs, o.Typeshed)
 				if err != nil {
 					return nil, fmt.Errorf("could not convert doc %+v: %s", pdoc, err)
 				}
@@ -176,13 +479,38 @@ func (p *pythonEnv) BuildGrapher(dir string, unit unit.SourceUnit, c *config.Rep
 			if err != nil {
 				return nil, fmt.Errorf("Could not marshal graph JSON: %s", err)
 			}
+			if err := schema.ValidateOutput(b); err != nil {
+				return nil, err
+			}
 			return b, nil
 		},
 	}, nil
 }
 
-func (p *pythonEnv) convertSym(pySym *pySym, c *config.Repository, reqs []requirement) (sym *graph.Symbol, selfref *graph.Ref, err error) {
-	symKey, err := p.pysonarSymPathToSymKey(pySym.Path, c, reqs)
+// namespacePackageDirs returns the set of pysonar file paths that are
+// directories holding sibling .py modules, i.e. PEP 420 implicit namespace
+// packages. These have no __init__.py of their own, so pysonar reports the
+// directory itself as a MODULE symbol rather than a file-backed one.
+func namespacePackageDirs(syms []*pySym) map[string]bool {
+	dirs := make(map[string]bool)
+	regular := make(map[string]bool)
+	for _, s := range syms {
+		if s.Kind == "MODULE" && strings.HasSuffix(s.File, ".py") {
+			if filepath.Base(s.File) == "__init__.py" {
+				regular[filepath.Dir(s.File)] = true
+				continue
+			}
+			dirs[filepath.Dir(s.File)] = true
+		}
+	}
+	for d := range regular {
+		delete(dirs, d)
+	}
+	return dirs
+}
+
+func (p *pythonEnv) convertSym(pySym *pySym, c *config.Repository, reqs []requirement, nsPkgDirs map[string]bool, typeshed typeshedIndex) (sym *graph.Symbol, selfref *graph.Ref, err error) {
+	symKey, err := p.pysonarSymPathToSymKey(pySym.Path, c, reqs, typeshed)
 	if err != nil {
 		return
 	}
@@ -219,6 +547,11 @@ func (p *pythonEnv) convertSym(pySym *pySym, c *config.Repository, reqs []requir
 	if pySym.Kind == "MODULE" && strings.HasSuffix(pySym.File, "__init__.py") {
 		sym.SpecificKind = Package
 		sym.Kind = graph.Package
+	} else if pySym.Kind == "MODULE" && !strings.HasSuffix(pySym.File, ".py") && nsPkgDirs[pySym.File] {
+		// A namespace package: pysonar reports the directory itself (no
+		// __init__.py) as the module's file.
+		sym.SpecificKind = Package
+		sym.Kind = graph.Package
 	}
 
 	if sym.File != "" && pySym.IdentStart != pySym.IdentEnd {
@@ -247,8 +580,8 @@ func (p *pythonEnv) convertSym(pySym *pySym, c *config.Repository, reqs []requir
 	return
 }
 
-func (p *pythonEnv) convertRef(pyRef *pyRef, c *config.Repository, reqs []requirement) (*graph.Ref, error) {
-	symKey, err := p.pysonarSymPathToSymKey(pyRef.Sym, c, reqs)
+func (p *pythonEnv) convertRef(pyRef *pyRef, c *config.Repository, reqs []requirement, typeshed typeshedIndex) (*graph.Ref, error) {
+	symKey, err := p.pysonarSymPathToSymKey(pyRef.Sym, c, reqs, typeshed)
 	if err != nil {
 		return nil, err
 	}
@@ -274,9 +607,9 @@ func (p *pythonEnv) convertRef(pyRef *pyRef, c *config.Repository, reqs []requir
 	}, nil
 }
 
-func (p *pythonEnv) convertDoc(pyDoc *pyDoc, c *config.Repository, reqs []requirement) (*graph.Doc, error) {
+func (p *pythonEnv) convertDoc(pyDoc *pyDoc, c *config.Repository, reqs []requirement, typeshed typeshedIndex) (*graph.Doc, error) {
 	// TODO: handle null byte (\x00) in doc body?
-	symKey, err := p.pysonarSymPathToSymKey(pyDoc.Sym, c, reqs)
+	symKey, err := p.pysonarSymPathToSymKey(pyDoc.Sym, c, reqs, typeshed)
 	if err != nil {
 		return nil, err
 	}
@@ -307,6 +640,10 @@ func (p *pythonEnv) pysonarFilePathToFile(pth string) (string, error) {
 }
 
 func (p *pythonEnv) pysonarFilePathToRepoAndFile(pth string, c *config.Repository, reqs []requirement) (repo.URI, string, error) {
+	// Namespace packages are reported by pysonar as a bare directory path
+	// (no __init__.py), so normalize away any trailing separator before
+	// relativizing it against srcRoot/sitePackagesDir/stdLibDir below.
+	pth = filepath.Clean(pth)
 	if relpath, err := filepath.Rel(srcRoot, pth); err == nil {
 		return c.URI, relpath, nil
 	} else if relpath, err := filepath.Rel(p.sitePackagesDir(), pth); err == nil {
@@ -332,6 +669,8 @@ func (p *pythonEnv) pysonarFilePathToRepoAndFile(pth string, c *config.Repositor
 			return "", "", fmt.Errorf("Could not resolve repo URL for file path %s", pth)
 		}
 		return repo.MakeURI(foundReq.RepoURL), relpath, nil
+	} else if editRepo, editRelpath, ok := p.editableRepoAndFile(pth, c, reqs); ok {
+		return editRepo, editRelpath, nil
 	} else if relpath, err := filepath.Rel(p.stdLibDir(), pth); err == nil {
 		return stdLibRepo, relpath, nil
 	} else {
@@ -339,7 +678,87 @@ func (p *pythonEnv) pysonarFilePathToRepoAndFile(pth string, c *config.Repositor
 	}
 }
 
-func (p *pythonEnv) pysonarSymPathToSymKey(pth string, c *config.Repository, reqs []requirement) (*graph.SymbolKey, error) {
+// editableRepoAndFile resolves a file path that fell under a requirement's
+// editable/local-path install (see EditableInstall on requirement) rather
+// than under sitePackagesDir. If the editable install itself resolves to a
+// path inside srcRoot, it's treated as part of the repo being graphed;
+// otherwise it's attributed to the requirement's own RepoURL.
+func (p *pythonEnv) editableRepoAndFile(pth string, c *config.Repository, reqs []requirement) (repo.URI, string, bool) {
+	for _, req := range reqs {
+		if req.EditableInstall == "" {
+			continue
+		}
+		relpath, err := filepath.Rel(req.EditableInstall, pth)
+		if err != nil || strings.HasPrefix(relpath, "..") {
+			continue
+		}
+		if srcRelpath, err := filepath.Rel(srcRoot, req.EditableInstall); err == nil && !strings.HasPrefix(srcRelpath, "..") {
+			return c.URI, filepath.Join(srcRelpath, relpath), true
+		}
+		return repo.MakeURI(req.RepoURL), relpath, true
+	}
+	return "", "", false
+}
+
+// typeshedIndex lists the stub modules available in the python/typeshed
+// checkout the Dockerfile templates clone to /typeshed. It travels home in
+// rawGraphData ("Typeshed" key) instead of being read off disk: Transform
+// runs on the host, long after the container holding that checkout has
+// already exited, so there is no /typeshed for it to os.Stat.
+type typeshedIndex struct {
+	Stdlib []string
+	Stubs  map[string][]string
+}
+
+func stringsContain(ss []string, s string) bool {
+	for _, x := range ss {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
+
+// typeshedSymKey looks up pth's top-level module name in typeshed, trying
+// the stdlib stubs first and then per-package third-party stubs for each
+// candidate requirement. A third-party package matches if its stubs hold
+// either that top-level module or, when the package is the top-level
+// module itself, the submodule that follows it in pth (numpy.core ->
+// stubs/numpy/core.pyi).
+func typeshedSymKey(pth string, reqs []requirement, typeshed typeshedIndex) (*graph.SymbolKey, bool) {
+	fauxUnit := &FauxPackage{}
+	parts := strings.SplitN(strings.TrimPrefix(pth, "."), ".", 3)
+	mod := parts[0]
+	if mod == "" {
+		return nil, false
+	}
+
+	if stringsContain(typeshed.Stdlib, mod) {
+		return &graph.SymbolKey{
+			Repo:     stdLibRepo,
+			UnitType: unit.Type(fauxUnit),
+			Unit:     fauxUnit.Name(),
+			Path:     graph.SymbolPath(pth),
+		}, true
+	}
+
+	for _, req := range reqs {
+		for _, pkg := range req.Packages {
+			stubs := typeshed.Stubs[pkg]
+			if stringsContain(stubs, mod) || (pkg == mod && len(parts) > 1 && stringsContain(stubs, parts[1])) {
+				return &graph.SymbolKey{
+					Repo:     repo.MakeURI(req.RepoURL),
+					UnitType: unit.Type(fauxUnit),
+					Unit:     fauxUnit.Name(),
+					Path:     graph.SymbolPath(pth),
+				}, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func (p *pythonEnv) pysonarSymPathToSymKey(pth string, c *config.Repository, reqs []requirement, typeshed typeshedIndex) (*graph.SymbolKey, error) {
 	fauxUnit := &FauxPackage{}
 	if relpath, err := filepath.Rel(srcRoot, pth); err == nil {
 		return &graph.SymbolKey{
@@ -368,6 +787,9 @@ func (p *pythonEnv) pysonarSymPathToSymKey(pth string, c *config.Repository, req
 			}
 		}
 		if foundReq == nil {
+			if symKey, ok := typeshedSymKey(pth, reqs, typeshed); ok {
+				return symKey, nil
+			}
 			return nil, fmt.Errorf("Could not find requirement matching path %s", pth)
 		}
 
@@ -377,6 +799,13 @@ func (p *pythonEnv) pysonarSymPathToSymKey(pth string, c *config.Repository, req
 			Unit:     fauxUnit.Name(),
 			Path:     graph.SymbolPath(relpath),
 		}, nil
+	} else if editRepo, editRelpath, ok := p.editableRepoAndFile(pth, c, reqs); ok {
+		return &graph.SymbolKey{
+			Repo:     editRepo,
+			UnitType: unit.Type(fauxUnit),
+			Unit:     fauxUnit.Name(),
+			Path:     graph.SymbolPath(editRelpath),
+		}, nil
 	} else if relpath, err := filepath.Rel(p.stdLibDir(), pth); err == nil {
 		return &graph.SymbolKey{
 			Repo:     stdLibRepo,
@@ -395,6 +824,9 @@ func (p *pythonEnv) pysonarSymPathToSymKey(pth string, c *config.Repository, req
 				}, nil
 			}
 		}
+		if symKey, ok := typeshedSymKey(pth, reqs, typeshed); ok {
+			return symKey, nil
+		}
 		return nil, fmt.Errorf("Could not find requirement matching path %s", pth)
 	}
 }
@@ -405,7 +837,8 @@ type rawGraphData struct {
 		Refs []*pyRef
 		Docs []*pyDoc
 	}
-	Reqs []requirement
+	Reqs     []requirement
+	Typeshed typeshedIndex
 }
 
 type pySym struct {
@@ -0,0 +1,138 @@
+package python
+
+import (
+	"strings"
+	"testing"
+
+	"sourcegraph.com/sourcegraph/srcgraph/config"
+	"sourcegraph.com/sourcegraph/srcgraph/repo"
+)
+
+func TestEditableRepoAndFile(t *testing.T) {
+	c := &config.Repository{URI: repo.URI("my/repo")}
+	reqs := []requirement{
+		{Name: "sibling", RepoURL: "", EditableInstall: "/src/sibling"},
+		{Name: "vendored", RepoURL: "github.com/other/vendored", EditableInstall: "/tmp/vendored-checkout"},
+	}
+	p := &pythonEnv{}
+
+	tests := []struct {
+		name      string
+		pth       string
+		wantRepo  repo.URI
+		wantPath  string
+		wantFound bool
+	}{
+		{
+			name:      "editable install under srcRoot resolves to the repo being graphed",
+			pth:       "/src/sibling/pkg/mod.py",
+			wantRepo:  c.URI,
+			wantPath:  "sibling/pkg/mod.py",
+			wantFound: true,
+		},
+		{
+			name:      "editable install outside srcRoot resolves to its own RepoURL",
+			pth:       "/tmp/vendored-checkout/pkg/mod.py",
+			wantRepo:  repo.URI("github.com/other/vendored"),
+			wantPath:  "pkg/mod.py",
+			wantFound: true,
+		},
+		{
+			name:      "path matching no editable install is left unresolved",
+			pth:       "/venv/lib/python2.7/site-packages/requests/api.py",
+			wantFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotRepo, gotPath, ok := p.editableRepoAndFile(tt.pth, c, reqs)
+			if ok != tt.wantFound {
+				t.Fatalf("editableRepoAndFile(%q) ok = %v, want %v", tt.pth, ok, tt.wantFound)
+			}
+			if !ok {
+				return
+			}
+			if gotRepo != tt.wantRepo || gotPath != tt.wantPath {
+				t.Errorf("editableRepoAndFile(%q) = (%q, %q), want (%q, %q)", tt.pth, gotRepo, gotPath, tt.wantRepo, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestNamespacePackageDirs(t *testing.T) {
+	syms := []*pySym{
+		{Kind: "MODULE", File: "/src/ns_pkg/sub.py"},
+		{Kind: "MODULE", File: "/src/ns_pkg/other.py"},
+		{Kind: "MODULE", File: "/src/regular_pkg/__init__.py"},
+		{Kind: "CLASS", File: "/src/ns_pkg/sub.py"},
+	}
+
+	dirs := namespacePackageDirs(syms)
+
+	if !dirs["/src/ns_pkg"] {
+		t.Errorf("expected /src/ns_pkg to be detected as a namespace package dir")
+	}
+	if dirs["/src/regular_pkg"] {
+		t.Errorf("did not expect /src/regular_pkg (has __init__.py) to be detected as a namespace package dir")
+	}
+}
+
+// TestJediAnalyzerCmdProducesRefs guards against regressing the Jedi
+// analyzer script back to an API call jedi 0.17.2 doesn't have: calling
+// name.goto_assignments() on every non-definition name raised AttributeError
+// there, which the script's own `except Exception: targets = []` swallowed,
+// so refs was always empty no matter what the tree being graphed contained.
+func TestJediAnalyzerCmdProducesRefs(t *testing.T) {
+	cmd := JediAnalyzer{}.Cmd(&pythonEnv{})
+	if len(cmd) != 3 {
+		t.Fatalf("JediAnalyzer{}.Cmd() = %d args, want 3 (/bin/bash, -c, script)", len(cmd))
+	}
+	script := cmd[2]
+
+	if strings.Contains(script, "goto_assignments") {
+		t.Errorf("script still calls the removed goto_assignments() API")
+	}
+	if !strings.Contains(script, "name.goto()") {
+		t.Errorf("script does not call name.goto() to resolve references")
+	}
+	if !strings.Contains(script, "'Builtin': False") {
+		t.Errorf("script does not emit the Builtin field the rawGraphData schema requires on every Ref")
+	}
+	if !strings.Contains(script, "KIND_MAP") {
+		t.Errorf("script does not translate jedi's name.type into pysonar's symbolKinds vocabulary")
+	}
+}
+
+func TestTypeshedSymKey(t *testing.T) {
+	idx := typeshedIndex{
+		Stdlib: []string{"_ssl", "os"},
+		Stubs:  map[string][]string{"numpy": {"core", "lib"}},
+	}
+	reqs := []requirement{
+		{Name: "numpy", RepoURL: "github.com/numpy/numpy", Packages: []string{"numpy"}},
+	}
+
+	tests := []struct {
+		name     string
+		pth      string
+		wantRepo repo.URI
+		wantOK   bool
+	}{
+		{name: "stdlib stub", pth: "_ssl.SSLContext", wantRepo: stdLibRepo, wantOK: true},
+		{name: "third-party stub via requirement's package", pth: "numpy.core.ndarray", wantRepo: repo.URI("github.com/numpy/numpy"), wantOK: true},
+		{name: "no matching stub", pth: "lxml.etree.Element", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			symKey, ok := typeshedSymKey(tt.pth, reqs, idx)
+			if ok != tt.wantOK {
+				t.Fatalf("typeshedSymKey(%q) ok = %v, want %v", tt.pth, ok, tt.wantOK)
+			}
+			if ok && symKey.Repo != tt.wantRepo {
+				t.Errorf("typeshedSymKey(%q).Repo = %q, want %q", tt.pth, symKey.Repo, tt.wantRepo)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+// Command gen regenerates toolchain/schema/schema_data.go from the
+// RawGraphData and grapher2.Output struct definitions. Run via
+// `make generate-schema`; don't invoke directly.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+
+	"github.com/invopop/jsonschema"
+
+	"sourcegraph.com/sourcegraph/srcgraph/grapher2"
+	"sourcegraph.com/sourcegraph/srclib-python/toolchain/schema"
+)
+
+const outPath = "schema_data.go"
+
+func main() {
+	rawGraphSchema, err := jsonschema.Reflect(&schema.RawGraphData{}).MarshalJSON()
+	if err != nil {
+		log.Fatalf("reflecting RawGraphData: %s", err)
+	}
+	outputSchema, err := jsonschema.Reflect(&grapher2.Output{}).MarshalJSON()
+	if err != nil {
+		log.Fatalf("reflecting grapher2.Output: %s", err)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "package schema\n\n")
+	fmt.Fprintf(&buf, "// Code generated by `make generate-schema` from RawGraphData and\n")
+	fmt.Fprintf(&buf, "// grapher2.Output via github.com/invopop/jsonschema. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "const rawGraphSchemaJSON = `%s`\n\n", rawGraphSchema)
+	fmt.Fprintf(&buf, "const outputSchemaJSON = `%s`\n", outputSchema)
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("formatting generated schema: %s", err)
+	}
+	if err := ioutil.WriteFile(outPath, formatted, 0644); err != nil {
+		log.Fatalf("writing %s: %s", outPath, err)
+	}
+}
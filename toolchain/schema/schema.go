@@ -0,0 +1,46 @@
+// Package schema validates the two JSON contracts BuildGrapher depends on:
+// the rawGraphData it unmarshals from the grapher container, and the
+// grapher2.Output it produces from that. Both schemas are generated from Go
+// structs by `make generate-schema` (see ./gen) and committed in
+// schema_data.go, so a drift check can catch a forgotten regeneration.
+package schema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// ValidateRawGraph checks orig against the rawGraphData schema before
+// BuildGrapher's Transform unmarshals it, so an analyzer change that breaks
+// the contract is reported with the failing field's location, e.g.
+// "pysonar output does not match schema: Graph.Syms.0: Path is required",
+// instead of a generic json: cannot unmarshal error. Each problem is given in
+// gojsonschema's own wording, with array positions as numeric indices.
+func ValidateRawGraph(orig []byte) error {
+	return validate(rawGraphSchemaJSON, orig, "pysonar output")
+}
+
+// ValidateOutput checks b, a marshaled grapher2.Output, against its schema.
+func ValidateOutput(b []byte) error {
+	return validate(outputSchemaJSON, b, "grapher output")
+}
+
+func validate(schemaJSON string, doc []byte, what string) error {
+	result, err := gojsonschema.Validate(
+		gojsonschema.NewStringLoader(schemaJSON),
+		gojsonschema.NewBytesLoader(doc),
+	)
+	if err != nil {
+		return fmt.Errorf("could not validate %s against schema: %s", what, err)
+	}
+	if !result.Valid() {
+		msgs := make([]string, len(result.Errors()))
+		for i, e := range result.Errors() {
+			msgs[i] = e.String()
+		}
+		return fmt.Errorf("%s does not match schema: %s", what, strings.Join(msgs, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package schema
+
+// Code generated by `make generate-schema` from RawGraphData and
+// grapher2.Output via github.com/invopop/jsonschema. DO NOT EDIT.
+//
+// Regenerate with `make generate-schema`; `make check-schema-drift` fails CI
+// if this file doesn't match what the generator produces from the current
+// struct definitions.
+
+const rawGraphSchemaJSON = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "https://github.com/davidhalter/srclib-python/toolchain/schema/rawgraph.schema.json",
+  "title": "RawGraphData",
+  "type": "object",
+  "required": ["Graph", "Reqs", "Typeshed"],
+  "properties": {
+    "Graph": {"$ref": "#/$defs/Graph"},
+    "Reqs": {
+      "type": "array",
+      "items": {"$ref": "#/$defs/Requirement"}
+    },
+    "Typeshed": {"$ref": "#/$defs/TypeshedIndex"}
+  },
+  "$defs": {
+    "Graph": {
+      "type": "object",
+      "required": ["Syms", "Refs", "Docs"],
+      "properties": {
+        "Syms": {
+          "type": "array",
+          "items": {"$ref": "#/$defs/Sym"}
+        },
+        "Refs": {
+          "type": "array",
+          "items": {"$ref": "#/$defs/Ref"}
+        },
+        "Docs": {
+          "type": "array",
+          "items": {"$ref": "#/$defs/Doc"}
+        }
+      }
+    },
+    "Sym": {
+      "type": "object",
+      "required": ["Path", "Name", "File", "IdentStart", "IdentEnd", "DefStart", "DefEnd", "Exported", "Kind"],
+      "properties": {
+        "Path": {"type": "string"},
+        "Name": {"type": "string"},
+        "File": {"type": "string"},
+        "IdentStart": {"type": "integer"},
+        "IdentEnd": {"type": "integer"},
+        "DefStart": {"type": "integer"},
+        "DefEnd": {"type": "integer"},
+        "Exported": {"type": "boolean"},
+        "Kind": {"type": "string"},
+        "FuncData": {"$ref": "#/$defs/FuncSignature"}
+      }
+    },
+    "FuncSignature": {
+      "type": "object",
+      "required": ["Signature"],
+      "properties": {
+        "Signature": {"type": "string"}
+      }
+    },
+    "Ref": {
+      "type": "object",
+      "required": ["Sym", "File", "Start", "End", "Builtin"],
+      "properties": {
+        "Sym": {"type": "string"},
+        "File": {"type": "string"},
+        "Start": {"type": "integer"},
+        "End": {"type": "integer"},
+        "Builtin": {"type": "boolean"}
+      }
+    },
+    "Doc": {
+      "type": "object",
+      "required": ["Sym", "File", "Body", "Start", "End"],
+      "properties": {
+        "Sym": {"type": "string"},
+        "File": {"type": "string"},
+        "Body": {"type": "string"},
+        "Start": {"type": "integer"},
+        "End": {"type": "integer"}
+      }
+    },
+    "Requirement": {
+      "type": "object",
+      "required": ["Name", "RepoURL", "Packages", "Modules"],
+      "properties": {
+        "Name": {"type": "string"},
+        "RepoURL": {"type": "string"},
+        "Packages": {"type": "array", "items": {"type": "string"}},
+        "Modules": {"type": "array", "items": {"type": "string"}},
+        "EditableInstall": {"type": "string"}
+      }
+    },
+    "TypeshedIndex": {
+      "type": "object",
+      "required": ["Stdlib", "Stubs"],
+      "properties": {
+        "Stdlib": {"type": "array", "items": {"type": "string"}},
+        "Stubs": {
+          "type": "object",
+          "additionalProperties": {"type": "array", "items": {"type": "string"}}
+        }
+      }
+    }
+  }
+}`
+
+const outputSchemaJSON = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "$id": "https://github.com/davidhalter/srclib-python/toolchain/schema/output.schema.json",
+  "title": "grapher2.Output",
+  "type": "object",
+  "required": ["Symbols", "Refs", "Docs"],
+  "properties": {
+    "Symbols": {
+      "type": "array",
+      "items": {"$ref": "#/$defs/Symbol"}
+    },
+    "Refs": {
+      "type": "array",
+      "items": {"$ref": "#/$defs/Ref"}
+    },
+    "Docs": {
+      "type": "array",
+      "items": {"$ref": "#/$defs/Doc"}
+    }
+  },
+  "$defs": {
+    "Symbol": {
+      "type": "object",
+      "required": ["Repo", "UnitType", "Unit", "Path", "Name", "Kind"],
+      "properties": {
+        "Repo": {"type": "string"},
+        "UnitType": {"type": "string"},
+        "Unit": {"type": "string"},
+        "Path": {"type": "string"},
+        "Name": {"type": "string"},
+        "File": {"type": "string"},
+        "DefStart": {"type": "integer"},
+        "DefEnd": {"type": "integer"},
+        "Exported": {"type": "boolean"},
+        "Callable": {"type": "boolean"},
+        "Kind": {"type": "string"},
+        "SpecificKind": {"type": "string"},
+        "SpecificPath": {"type": "string"},
+        "TypeExpr": {"type": "string"}
+      }
+    },
+    "Ref": {
+      "type": "object",
+      "required": ["SymbolRepo", "SymbolPath", "Repo", "File"],
+      "properties": {
+        "SymbolRepo": {"type": "string"},
+        "SymbolUnitType": {"type": "string"},
+        "SymbolUnit": {"type": "string"},
+        "SymbolPath": {"type": "string"},
+        "Def": {"type": "boolean"},
+        "Repo": {"type": "string"},
+        "UnitType": {"type": "string"},
+        "Unit": {"type": "string"},
+        "File": {"type": "string"},
+        "Start": {"type": "integer"},
+        "End": {"type": "integer"}
+      }
+    },
+    "Doc": {
+      "type": "object",
+      "required": ["Repo", "Path", "File", "Data"],
+      "properties": {
+        "Repo": {"type": "string"},
+        "UnitType": {"type": "string"},
+        "Unit": {"type": "string"},
+        "Path": {"type": "string"},
+        "Format": {"type": "string"},
+        "Data": {"type": "string"},
+        "File": {"type": "string"},
+        "Start": {"type": "integer"},
+        "End": {"type": "integer"}
+      }
+    }
+  }
+}`
@@ -0,0 +1,70 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateRawGraph(t *testing.T) {
+	valid := []byte(`{
+		"Graph": {
+			"Syms": [{
+				"Path": "a.py:f", "Name": "f", "File": "a.py",
+				"IdentStart": 0, "IdentEnd": 1, "DefStart": 0, "DefEnd": 1,
+				"Exported": true, "Kind": "FUNC"
+			}],
+			"Refs": [{"Sym": "a.py:f", "File": "b.py", "Start": 0, "End": 1, "Builtin": false}],
+			"Docs": [{"Sym": "a.py:f", "File": "a.py", "Body": "doc", "Start": 0, "End": 3}]
+		},
+		"Reqs": [{"Name": "requests", "RepoURL": "github.com/kennethreitz/requests", "Packages": [], "Modules": []}],
+		"Typeshed": {"Stdlib": ["os"], "Stubs": {}}
+	}`)
+	if err := ValidateRawGraph(valid); err != nil {
+		t.Errorf("ValidateRawGraph(valid) = %s, want nil", err)
+	}
+
+	missingPath := []byte(`{
+		"Graph": {
+			"Syms": [{
+				"Name": "f", "File": "a.py",
+				"IdentStart": 0, "IdentEnd": 1, "DefStart": 0, "DefEnd": 1,
+				"Exported": true, "Kind": "FUNC"
+			}],
+			"Refs": [],
+			"Docs": []
+		},
+		"Reqs": [],
+		"Typeshed": {"Stdlib": [], "Stubs": {}}
+	}`)
+	err := ValidateRawGraph(missingPath)
+	if err == nil {
+		t.Fatal("ValidateRawGraph(missingPath) = nil, want an error")
+	}
+	if !strings.Contains(err.Error(), "Path") {
+		t.Errorf("ValidateRawGraph(missingPath) error = %q, want it to mention the missing Path field", err)
+	}
+}
+
+func TestValidateOutput(t *testing.T) {
+	valid := []byte(`{
+		"Symbols": [{"Repo": "r", "UnitType": "u", "Unit": "n", "Path": "p", "Name": "f", "Kind": "func"}],
+		"Refs": [{"SymbolRepo": "r", "SymbolPath": "p", "Repo": "r", "File": "a.py"}],
+		"Docs": [{"Repo": "r", "Path": "p", "File": "a.py", "Data": "doc"}]
+	}`)
+	if err := ValidateOutput(valid); err != nil {
+		t.Errorf("ValidateOutput(valid) = %s, want nil", err)
+	}
+
+	missingKind := []byte(`{
+		"Symbols": [{"Repo": "r", "UnitType": "u", "Unit": "n", "Path": "p", "Name": "f"}],
+		"Refs": [],
+		"Docs": []
+	}`)
+	err := ValidateOutput(missingKind)
+	if err == nil {
+		t.Fatal("ValidateOutput(missingKind) = nil, want an error")
+	}
+	if !strings.Contains(err.Error(), "Kind") {
+		t.Errorf("ValidateOutput(missingKind) error = %q, want it to mention the missing Kind field", err)
+	}
+}
@@ -0,0 +1,77 @@
+package schema
+
+// The types below mirror the unexported rawGraphData contract in package
+// python (toolchain/grapher.go) field-for-field. They exist only so
+// toolchain/schema/gen can reflect on an exported type to regenerate
+// rawGraphSchemaJSON in schema_data.go; nothing else should reference them.
+// Keep this in sync by hand whenever rawGraphData's shape changes, then run
+// `make generate-schema`.
+
+// RawGraphData mirrors python.rawGraphData.
+type RawGraphData struct {
+	Graph    Graph
+	Reqs     []Requirement
+	Typeshed TypeshedIndex
+}
+
+// Graph mirrors the anonymous Graph field of python.rawGraphData. It's
+// pulled out into its own named type (rather than left as an inline struct)
+// so jsonschema.Reflect emits it as a $defs entry instead of inlining it.
+type Graph struct {
+	Syms []*Sym
+	Refs []*Ref
+	Docs []*Doc
+}
+
+// TypeshedIndex mirrors python.typeshedIndex.
+type TypeshedIndex struct {
+	Stdlib []string
+	Stubs  map[string][]string
+}
+
+// Sym mirrors python.pySym.
+type Sym struct {
+	Path       string
+	Name       string
+	File       string
+	IdentStart int
+	IdentEnd   int
+	DefStart   int
+	DefEnd     int
+	Exported   bool
+	Kind       string
+	FuncData   *FuncSignature `json:",omitempty"`
+}
+
+// FuncSignature mirrors the anonymous FuncData field of python.pySym, again
+// named so it reflects into a $defs entry rather than an inline object.
+type FuncSignature struct {
+	Signature string
+}
+
+// Ref mirrors python.pyRef.
+type Ref struct {
+	Sym     string
+	File    string
+	Start   int
+	End     int
+	Builtin bool
+}
+
+// Doc mirrors python.pyDoc.
+type Doc struct {
+	Sym   string
+	File  string
+	Body  string
+	Start int
+	End   int
+}
+
+// Requirement mirrors python.requirement.
+type Requirement struct {
+	Name            string
+	RepoURL         string
+	Packages        []string
+	Modules         []string
+	EditableInstall string `json:",omitempty"`
+}